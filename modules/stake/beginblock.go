@@ -0,0 +1,112 @@
+package stake
+
+import (
+	crypto "github.com/tendermint/go-crypto"
+
+	"github.com/cosmos/cosmos-sdk"
+)
+
+// Evidence is the subset of Tendermint double-sign evidence BeginBlocker
+// consumes: which validator misbehaved, and at what height.
+type Evidence struct {
+	PubKey crypto.PubKey
+	Height uint64
+}
+
+// SigningValidator reports whether a validator signed the previous block -
+// the subset of Tendermint's per-block signing set BeginBlocker needs for
+// downtime detection.
+type SigningValidator struct {
+	PubKey crypto.PubKey
+	Signed bool
+}
+
+// SigningInfoStore is the minimal persistent key-value interface
+// BeginBlocker needs to read and update each validator's SigningInfo.
+type SigningInfoStore interface {
+	GetSigningInfo(key []byte) (info SigningInfo, found bool)
+	SetSigningInfo(key []byte, info SigningInfo)
+}
+
+// BeginBlocker consumes this block's double-sign evidence and the prior
+// block's signing set off the ABCI BeginBlock request, applying Slash and
+// Revoke for byzantine evidence and tracking/penalizing downtime via each
+// validator's rolling MissedBitArray. It is meant to run before any
+// transaction in the block is processed, mirroring EndBlocker's placement
+// at the end.
+//
+// queueStore is loaded once up front: its pending QueueElemUnbond entries
+// are passed to every Slash call, and its pending QueueElemRedelegate
+// entries to SlashRedelegationQueue, so that stake which began unbonding, or
+// was redelegated away, at or after the infraction height is diluted along
+// with the delegatee's ExchangeRate. Both queues are persisted back at the
+// end.
+func BeginBlocker(store SigningInfoStore, queueStore QueueStore, bonds DelegateeBonds, evidence []Evidence, signing []SigningValidator, currentHeight uint64, params SlashParams) (tags []SlashResult) {
+
+	queue, queueKeys := loadUnbondQueue(queueStore)
+	redelegations, redelKeys := loadRedelegationQueue(queueStore)
+
+	slashRedelegations := func(addr sdk.Actor, fraction Decimal, height uint64) {
+		for _, q := range redelegations {
+			SlashRedelegationQueue(addr, fraction, height, q)
+		}
+	}
+
+	for _, ev := range evidence {
+		if currentHeight > ev.Height+params.MaxEvidenceAge {
+			continue // evidence too old to act on
+		}
+		addr := sdk.Actor{Address: ev.PubKey.Address()}
+		res, err := bonds.Slash(addr, params.SlashFractionDoubleSign, ev.Height, queue)
+		if err != nil {
+			continue // no bond for this pubkey; nothing to slash
+		}
+		bonds.Revoke(addr)
+		slashRedelegations(addr, params.SlashFractionDoubleSign, ev.Height)
+		tags = append(tags, res)
+	}
+
+	for _, sv := range signing {
+		addr := sdk.Actor{Address: sv.PubKey.Address()}
+		key := GetSigningInfoKey(sv.PubKey)
+
+		info, found := store.GetSigningInfo(key)
+		if !found {
+			info = SigningInfo{StartHeight: currentHeight}
+		}
+		if len(info.MissedBitArray) != int(params.SignedBlocksWindow) {
+			info.MissedBitArray = make([]bool, params.SignedBlocksWindow)
+		}
+
+		idx := info.IndexOffset % params.SignedBlocksWindow
+		previouslyMissed := info.MissedBitArray[idx]
+		missedThisBlock := !sv.Signed
+
+		switch {
+		case missedThisBlock && !previouslyMissed:
+			info.MissedBlocksCounter++
+		case !missedThisBlock && previouslyMissed:
+			info.MissedBlocksCounter--
+		}
+		info.MissedBitArray[idx] = missedThisBlock
+		info.IndexOffset++
+
+		minSigned := NewDecimal(int64(params.SignedBlocksWindow)).Mul(params.MinSignedPerWindow)
+		signedCount := NewDecimal(int64(params.SignedBlocksWindow) - int64(info.MissedBlocksCounter))
+
+		if info.JailedUntil == 0 && info.IndexOffset >= params.SignedBlocksWindow && signedCount.LT(minSigned) {
+			if res, err := bonds.Slash(addr, params.SlashFractionDowntime, currentHeight, queue); err == nil {
+				bonds.Revoke(addr)
+				slashRedelegations(addr, params.SlashFractionDowntime, currentHeight)
+				info.JailedUntil = currentHeight + params.DowntimeJailDuration
+				tags = append(tags, res)
+			}
+		}
+
+		store.SetSigningInfo(key, info)
+	}
+
+	saveUnbondQueue(queueStore, queueKeys, queue)
+	saveRedelegationQueue(queueStore, redelKeys, redelegations)
+	return tags
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	txcmd "github.com/cosmos/cosmos-sdk/client/commands/txs"
+	"github.com/cosmos/gaia/modules/stake"
+)
+
+//nolint
+var (
+	CmdWithdrawFees = &cobra.Command{
+		Use:   "withdraw-fees",
+		Short: "withdraw accumulated fees for a delegator bond",
+		Long:  "withdraw accumulated fees for a delegator bond",
+		RunE:  cmdWithdrawFees,
+	}
+
+	CmdWithdrawCommission = &cobra.Command{
+		Use:   "withdraw-commission",
+		Short: "withdraw a validator's commission on accumulated fees",
+		Long:  "withdraw a validator's commission on accumulated fees",
+		RunE:  cmdWithdrawCommission,
+	}
+)
+
+func init() {
+	fsPk := flag.NewFlagSet("", flag.ContinueOnError)
+	fsPk.String(FlagPubKey, "", "pubkey of the validator-candidate")
+
+	CmdWithdrawFees.Flags().AddFlagSet(fsPk)
+	CmdWithdrawCommission.Flags().AddFlagSet(fsPk)
+}
+
+func cmdWithdrawFees(cmd *cobra.Command, args []string) error {
+
+	pk, err := GetPubKey(viper.GetString(FlagPubKey))
+	if err != nil {
+		return err
+	}
+
+	tx := stake.NewTxWithdrawFees(pk)
+	return txcmd.DoTx(tx)
+}
+
+func cmdWithdrawCommission(cmd *cobra.Command, args []string) error {
+
+	pk, err := GetPubKey(viper.GetString(FlagPubKey))
+	if err != nil {
+		return err
+	}
+
+	tx := stake.NewTxWithdrawCommission(pk)
+	return txcmd.DoTx(tx)
+}
@@ -7,6 +7,7 @@ import (
 
 	crypto "github.com/tendermint/go-crypto"
 
+	sdk "github.com/cosmos/cosmos-sdk"
 	"github.com/cosmos/cosmos-sdk/client/commands"
 	"github.com/cosmos/cosmos-sdk/client/commands/query"
 	"github.com/cosmos/cosmos-sdk/modules/coin"
@@ -32,8 +33,8 @@ var (
 
 	CmdQueryDelegatorBond = &cobra.Command{
 		Use:   "delegator-bond",
-		Short: "query a delegators bond based on address and candidate pubkey",
-		Long:  "query a delegators bond based on address and candidate pubkey",
+		Short: "query a delegators bond based on address and candidate address",
+		Long:  "query a delegators bond based on address and candidate address",
 		RunE:  cmdQueryDelegatorBond,
 	}
 
@@ -44,47 +45,86 @@ var (
 		RunE:  cmdQueryDelegatorCandidates,
 	}
 
-	FlagDelegatorAddress = "delegator-address"
+	CmdQueryFeePool = &cobra.Command{
+		Use:   "fee-pool",
+		Short: "query the global fee distribution pool",
+		Long:  "query the global fee distribution pool",
+		RunE:  cmdQueryFeePool,
+	}
+
+	FlagDelegatorAddress  = "delegator-address"
+	FlagAddressValidator  = "address-validator"
+	FlagWithRedelegations = "with-redelegations"
 )
 
 func init() {
 	//Add Flags
-	fsPk := flag.NewFlagSet("", flag.ContinueOnError)
-	fsPk.String(FlagPubKey, "", "pubkey of the validator-candidate")
+	fsValAddr := flag.NewFlagSet("", flag.ContinueOnError)
+	fsValAddr.String(FlagAddressValidator, "", "bech32/hex address of the validator-candidate")
+	fsValAddr.String(FlagPubKey, "", "pubkey of the validator-candidate, used when --address-validator is not given")
 	fsAddr := flag.NewFlagSet("", flag.ContinueOnError)
 	fsAddr.String(FlagDelegatorAddress, "", "delegator hex address")
+	fsRedel := flag.NewFlagSet("", flag.ContinueOnError)
+	fsRedel.Bool(FlagWithRedelegations, false, "also list pending redelegation destinations")
 
-	CmdQueryCandidate.Flags().AddFlagSet(fsPk)
-	CmdQueryDelegatorBond.Flags().AddFlagSet(fsPk)
+	CmdQueryCandidate.Flags().AddFlagSet(fsValAddr)
+	CmdQueryDelegatorBond.Flags().AddFlagSet(fsValAddr)
 	CmdQueryDelegatorBond.Flags().AddFlagSet(fsAddr)
 	CmdQueryDelegatorCandidates.Flags().AddFlagSet(fsAddr)
+	CmdQueryDelegatorCandidates.Flags().AddFlagSet(fsRedel)
+}
+
+// getValidatorAddr resolves the --address-validator flag, or, for
+// bootstrapping against candidates declared before this migration, falls
+// back to the legacy --pubkey flag via the secondary pubkey->address index.
+func getValidatorAddr() (sdk.Actor, error) {
+	if addrStr := viper.GetString(FlagAddressValidator); addrStr != "" {
+		addr, err := commands.ParseActor(addrStr)
+		if err != nil {
+			return sdk.Actor{}, err
+		}
+		return coin.ChainAddr(addr), nil
+	}
+
+	pk, err := GetPubKey(viper.GetString(FlagPubKey))
+	if err != nil {
+		return sdk.Actor{}, err
+	}
+
+	var addr sdk.Actor
+	prove := !viper.GetBool(commands.FlagTrustNode)
+	key := stack.PrefixedKey(stake.Name(), stake.GetPubKeyIndexKey(pk))
+	if _, err := query.GetParsed(key, &addr, query.GetHeight(), prove); err != nil {
+		return sdk.Actor{}, err
+	}
+	return addr, nil
 }
 
 func cmdQueryCandidates(cmd *cobra.Command, args []string) error {
 
-	var pks []crypto.PubKey
+	var addrs []sdk.Actor
 
 	prove := !viper.GetBool(commands.FlagTrustNode)
-	key := stack.PrefixedKey(stake.Name(), stake.CandidatesPubKeysKey)
-	height, err := query.GetParsed(key, &pks, query.GetHeight(), prove)
+	key := stack.PrefixedKey(stake.Name(), stake.CandidatesAddressesKey)
+	height, err := query.GetParsed(key, &addrs, query.GetHeight(), prove)
 	if err != nil {
 		return err
 	}
 
-	return query.OutputProof(pks, height)
+	return query.OutputProof(addrs, height)
 }
 
 func cmdQueryCandidate(cmd *cobra.Command, args []string) error {
 
 	var candidate stake.Candidate
 
-	pk, err := GetPubKey(viper.GetString(FlagPubKey))
+	addr, err := getValidatorAddr()
 	if err != nil {
 		return err
 	}
 
 	prove := !viper.GetBool(commands.FlagTrustNode)
-	key := stack.PrefixedKey(stake.Name(), stake.GetCandidateKey(pk))
+	key := stack.PrefixedKey(stake.Name(), stake.GetCandidateKey(addr))
 	height, err := query.GetParsed(key, &candidate, query.GetHeight(), prove)
 	if err != nil {
 		return err
@@ -93,11 +133,18 @@ func cmdQueryCandidate(cmd *cobra.Command, args []string) error {
 	return query.OutputProof(candidate, height)
 }
 
+// delegatorBondOutput wraps a DelegatorBond with its currently withdrawable
+// fees, computed against the global fee pool at query time.
+type delegatorBondOutput struct {
+	stake.DelegatorBond
+	WithdrawableFees coin.Coins `json:"withdrawable_fees"`
+}
+
 func cmdQueryDelegatorBond(cmd *cobra.Command, args []string) error {
 
 	var bond stake.DelegatorBond
 
-	pk, err := GetPubKey(viper.GetString(FlagPubKey))
+	valAddr, err := getValidatorAddr()
 	if err != nil {
 		return err
 	}
@@ -110,13 +157,45 @@ func cmdQueryDelegatorBond(cmd *cobra.Command, args []string) error {
 	delegator = coin.ChainAddr(delegator)
 
 	prove := !viper.GetBool(commands.FlagTrustNode)
-	key := stack.PrefixedKey(stake.Name(), stake.GetDelegatorBondKey(delegator, pk))
+	key := stack.PrefixedKey(stake.Name(), stake.GetDelegatorBondKey(delegator, valAddr))
 	height, err := query.GetParsed(key, &bond, query.GetHeight(), prove)
 	if err != nil {
 		return err
 	}
 
-	return query.OutputProof(bond, height)
+	var pool stake.FeePool
+	poolKey := stack.PrefixedKey(stake.Name(), stake.FeePoolKey)
+	if _, err := query.GetParsed(poolKey, &pool, query.GetHeight(), false); err != nil {
+		return err
+	}
+
+	out := delegatorBondOutput{
+		DelegatorBond:    bond,
+		WithdrawableFees: pool.WithdrawableFor(bond),
+	}
+
+	return query.OutputProof(out, height)
+}
+
+func cmdQueryFeePool(cmd *cobra.Command, args []string) error {
+
+	var pool stake.FeePool
+
+	prove := !viper.GetBool(commands.FlagTrustNode)
+	key := stack.PrefixedKey(stake.Name(), stake.FeePoolKey)
+	height, err := query.GetParsed(key, &pool, query.GetHeight(), prove)
+	if err != nil {
+		return err
+	}
+
+	return query.OutputProof(pool, height)
+}
+
+// delegatorCandidatesOutput optionally carries the destinations of a
+// delegator's pending redelegations alongside its settled candidates.
+type delegatorCandidatesOutput struct {
+	Candidates               []crypto.PubKey `json:"candidates"`
+	PendingRedelegationAddrs []sdk.Actor     `json:"pending_redelegation_addrs,omitempty"`
 }
 
 func cmdQueryDelegatorCandidates(cmd *cobra.Command, args []string) error {
@@ -136,5 +215,18 @@ func cmdQueryDelegatorCandidates(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	return query.OutputProof(candidates, height)
+	out := delegatorCandidatesOutput{Candidates: candidates}
+
+	if viper.GetBool(FlagWithRedelegations) {
+		var redelegations []stake.QueueElemRedelegate
+		redelKey := stack.PrefixedKey(stake.Name(), stake.GetRedelegationsKey(delegator))
+		if _, err := query.GetParsed(redelKey, &redelegations, query.GetHeight(), false); err != nil {
+			return err
+		}
+		for _, r := range redelegations {
+			out.PendingRedelegationAddrs = append(out.PendingRedelegationAddrs, r.DstDelegatee)
+		}
+	}
+
+	return query.OutputProof(out, height)
 }
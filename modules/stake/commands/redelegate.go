@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/commands"
+	"github.com/cosmos/cosmos-sdk/client/commands/query"
+	txcmd "github.com/cosmos/cosmos-sdk/client/commands/txs"
+	"github.com/cosmos/cosmos-sdk/modules/coin"
+	"github.com/cosmos/cosmos-sdk/stack"
+	"github.com/cosmos/gaia/modules/stake"
+)
+
+//nolint
+var (
+	CmdRedelegate = &cobra.Command{
+		Use:   "redelegate",
+		Short: "move a bond from one validator-candidate to another",
+		Long:  "move a bond from one validator-candidate to another",
+		RunE:  cmdRedelegate,
+	}
+
+	CmdQueryRedelegations = &cobra.Command{
+		Use:   "redelegations",
+		Short: "query a delegator's pending redelegations",
+		Long:  "query a delegator's pending redelegations",
+		RunE:  cmdQueryRedelegations,
+	}
+
+	FlagSrcPubKey = "src-pubkey"
+	FlagDstPubKey = "dst-pubkey"
+	FlagAmount    = "amount"
+)
+
+func init() {
+	fsRedelegate := flag.NewFlagSet("", flag.ContinueOnError)
+	fsRedelegate.String(FlagSrcPubKey, "", "pubkey of the source validator-candidate")
+	fsRedelegate.String(FlagDstPubKey, "", "pubkey of the destination validator-candidate")
+	fsRedelegate.String(FlagAmount, "", "amount of bond tokens to redelegate")
+	CmdRedelegate.Flags().AddFlagSet(fsRedelegate)
+
+	fsAddr := flag.NewFlagSet("", flag.ContinueOnError)
+	fsAddr.String(FlagDelegatorAddress, "", "delegator hex address")
+	CmdQueryRedelegations.Flags().AddFlagSet(fsAddr)
+}
+
+func cmdRedelegate(cmd *cobra.Command, args []string) error {
+
+	src, err := GetPubKey(viper.GetString(FlagSrcPubKey))
+	if err != nil {
+		return err
+	}
+
+	dst, err := GetPubKey(viper.GetString(FlagDstPubKey))
+	if err != nil {
+		return err
+	}
+
+	amount, err := stake.ParseDecimal(viper.GetString(FlagAmount))
+	if err != nil {
+		return err
+	}
+
+	tx := stake.NewTxRedelegate(src, dst, amount)
+	return txcmd.DoTx(tx)
+}
+
+func cmdQueryRedelegations(cmd *cobra.Command, args []string) error {
+
+	delegatorAddr := viper.GetString(FlagDelegatorAddress)
+	delegator, err := commands.ParseActor(delegatorAddr)
+	if err != nil {
+		return err
+	}
+	delegator = coin.ChainAddr(delegator)
+
+	var redelegations []stake.QueueElemRedelegate
+	prove := !viper.GetBool(commands.FlagTrustNode)
+	key := stack.PrefixedKey(stake.Name(), stake.GetRedelegationsKey(delegator))
+	height, err := query.GetParsed(key, &redelegations, query.GetHeight(), prove)
+	if err != nil {
+		return err
+	}
+
+	return query.OutputProof(redelegations, height)
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/commands"
+	"github.com/cosmos/cosmos-sdk/client/commands/query"
+	"github.com/cosmos/cosmos-sdk/stack"
+	"github.com/cosmos/gaia/modules/stake"
+)
+
+//nolint
+var (
+	CmdQuerySigningInfo = &cobra.Command{
+		Use:   "signing-info",
+		Short: "query a validator-candidate's recent block-signing record",
+		Long:  "query a validator-candidate's recent block-signing record",
+		RunE:  cmdQuerySigningInfo,
+	}
+
+	CmdQuerySlashParams = &cobra.Command{
+		Use:   "slash-params",
+		Short: "query the slashing subsystem's parameters",
+		Long:  "query the slashing subsystem's parameters",
+		RunE:  cmdQuerySlashParams,
+	}
+)
+
+func init() {
+	fsPk := flag.NewFlagSet("", flag.ContinueOnError)
+	fsPk.String(FlagPubKey, "", "pubkey of the validator-candidate")
+	CmdQuerySigningInfo.Flags().AddFlagSet(fsPk)
+}
+
+func cmdQuerySigningInfo(cmd *cobra.Command, args []string) error {
+
+	var info stake.SigningInfo
+
+	pk, err := GetPubKey(viper.GetString(FlagPubKey))
+	if err != nil {
+		return err
+	}
+
+	prove := !viper.GetBool(commands.FlagTrustNode)
+	key := stack.PrefixedKey(stake.Name(), stake.GetSigningInfoKey(pk))
+	height, err := query.GetParsed(key, &info, query.GetHeight(), prove)
+	if err != nil {
+		return err
+	}
+
+	return query.OutputProof(info, height)
+}
+
+func cmdQuerySlashParams(cmd *cobra.Command, args []string) error {
+
+	var params stake.SlashParams
+
+	prove := !viper.GetBool(commands.FlagTrustNode)
+	key := stack.PrefixedKey(stake.Name(), stake.SlashParamsKey)
+	height, err := query.GetParsed(key, &params, query.GetHeight(), prove)
+	if err != nil {
+		return err
+	}
+
+	return query.OutputProof(params, height)
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/commands"
+	"github.com/cosmos/cosmos-sdk/client/commands/query"
+	"github.com/cosmos/cosmos-sdk/modules/coin"
+	"github.com/cosmos/cosmos-sdk/stack"
+	"github.com/cosmos/gaia/modules/stake"
+)
+
+//nolint
+var CmdQueryUnbondingDelegations = &cobra.Command{
+	Use:   "unbonding-delegations",
+	Short: "query a delegator's pending unbondings",
+	Long:  "query a delegator's pending unbondings",
+	RunE:  cmdQueryUnbondingDelegations,
+}
+
+func init() {
+	fsAddr := flag.NewFlagSet("", flag.ContinueOnError)
+	fsAddr.String(FlagDelegatorAddress, "", "delegator hex address")
+	CmdQueryUnbondingDelegations.Flags().AddFlagSet(fsAddr)
+}
+
+func cmdQueryUnbondingDelegations(cmd *cobra.Command, args []string) error {
+
+	delegatorAddr := viper.GetString(FlagDelegatorAddress)
+	delegator, err := commands.ParseActor(delegatorAddr)
+	if err != nil {
+		return err
+	}
+	delegator = coin.ChainAddr(delegator)
+
+	var unbondings []stake.QueueElemUnbond
+	prove := !viper.GetBool(commands.FlagTrustNode)
+	key := stack.PrefixedKey(stake.Name(), stake.GetUnbondingDelegationsKey(delegator))
+	height, err := query.GetParsed(key, &unbondings, query.GetHeight(), prove)
+	if err != nil {
+		return err
+	}
+
+	return query.OutputProof(unbondings, height)
+}
@@ -0,0 +1,225 @@
+package stake
+
+import (
+	"encoding/binary"
+
+	abci "github.com/tendermint/abci/types"
+	wire "github.com/tendermint/go-wire"
+
+	"github.com/cosmos/cosmos-sdk"
+)
+
+// UnbondingPeriod is the number of blocks a QueueElemUnbond must wait after
+// HeightAtInit before its bond tokens are paid out.
+const UnbondingPeriod = uint64(60 * 60 * 24 * 21 / 5) // ~21 days at a 5s block time
+
+// MaxCommissionChangePerDay caps how much a single QueueElemModComm may move
+// a validator's Commission by once matured, regardless of what was
+// requested at submission time.
+var MaxCommissionChangePerDay = NewDecimal(1).Quo(NewDecimal(100)) // 1%
+
+const (
+	unbondQueuePrefix = "UnbondQueue/"
+	commQueuePrefix   = "CommQueue/"
+)
+
+// QueueStore is the minimal persistent key-value interface EndBlocker needs
+// to scan and mutate the unbonding and commission-change queues.
+type QueueStore interface {
+	Set(key, value []byte)
+	Delete(key []byte)
+	// Iterate calls fn, in ascending key order, for every entry with a key
+	// in [start, end); it stops early if fn returns false.
+	Iterate(start, end []byte, fn func(key, value []byte) bool)
+}
+
+// BankKeeper is the minimal coin-transfer interface EndBlocker needs to pay
+// out matured unbondings. amount is already converted to coins via the
+// exchange rate at payout time.
+type BankKeeper interface {
+	Transfer(from, to sdk.Actor, amount Decimal) error
+}
+
+// maturityKey builds a bigendian height-prefixed store key so entries can
+// be scanned for maturity in O(log n) via a single range iteration, rather
+// than a linear scan of the whole queue.
+func maturityKey(prefix string, maturesAt uint64, delegatee sdk.Actor) []byte {
+	h := make([]byte, 8)
+	binary.BigEndian.PutUint64(h, maturesAt)
+	return append(append([]byte(prefix), h...), delegatee.Address...)
+}
+
+// GetUnbondingDelegationsKey returns the store key under which a
+// delegator's pending QueueElemUnbond entries are listed as a single slice,
+// for CmdQueryUnbondingDelegations.
+func GetUnbondingDelegationsKey(delegator sdk.Actor) []byte {
+	return append([]byte("UnbondingDelegations/"), delegator.Address...)
+}
+
+// prefixRangeEnd returns the exclusive end key of the range covering every
+// key starting with prefix, by incrementing its last byte and carrying into
+// earlier bytes on overflow.
+func prefixRangeEnd(prefix string) []byte {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end
+		}
+	}
+	return nil // prefix was all 0xff; every key matches, so there is no end
+}
+
+// loadUnbondQueue reads every pending QueueElemUnbond out of store,
+// regardless of maturity, alongside the store key it was read from so the
+// same slot can be overwritten once an entry is mutated in place (by
+// DelegateeBonds.Slash, for example).
+func loadUnbondQueue(store QueueStore) (elems []QueueElemUnbond, keys [][]byte) {
+	store.Iterate([]byte(unbondQueuePrefix), prefixRangeEnd(unbondQueuePrefix), func(key, value []byte) bool {
+		var elem QueueElemUnbond
+		wire.ReadBinaryBytes(value, &elem)
+		elems = append(elems, elem)
+		keys = append(keys, append([]byte{}, key...))
+		return true
+	})
+	return elems, keys
+}
+
+// saveUnbondQueue writes elems back to the keys they were loaded from by
+// loadUnbondQueue. Mutating entries by BondTokens alone never changes an
+// entry's maturity key, so this is always a same-slot overwrite.
+func saveUnbondQueue(store QueueStore, keys [][]byte, elems []QueueElemUnbond) {
+	for i, key := range keys {
+		store.Set(key, wire.BinaryBytes(elems[i]))
+	}
+}
+
+// PushUnbondQueue schedules elem to mature at HeightAtInit+UnbondingPeriod.
+func PushUnbondQueue(store QueueStore, elem QueueElemUnbond) {
+	key := maturityKey(unbondQueuePrefix, elem.HeightAtInit+UnbondingPeriod, elem.Delegatee)
+	store.Set(key, wire.BinaryBytes(elem))
+}
+
+// PushCommQueue schedules elem to mature at HeightAtInit+UnbondingPeriod.
+func PushCommQueue(store QueueStore, elem QueueElemModComm) {
+	key := maturityKey(commQueuePrefix, elem.HeightAtInit+UnbondingPeriod, elem.Delegatee)
+	store.Set(key, wire.BinaryBytes(elem))
+}
+
+// popMatured drains every entry in prefix whose maturity key is
+// <= currentHeight, decoding each with apply. apply reports whether the
+// entry is actually ready to leave the queue - an entry that has reached
+// its HeightAtInit+UnbondingPeriod bucket but carries a CompletionTime
+// still in the future is left in place so a later block can re-check it.
+// A panic while decoding a single entry is recovered so one malformed
+// entry can't halt the chain; that entry is dropped and skipped.
+func popMatured(store QueueStore, prefix string, currentHeight uint64, apply func(value []byte) (ready bool)) {
+	end := make([]byte, 8)
+	binary.BigEndian.PutUint64(end, currentHeight+1)
+	end = append([]byte(prefix), end...)
+
+	var keys [][]byte
+	store.Iterate([]byte(prefix), end, func(key, value []byte) bool {
+		ready := func() (ready bool) {
+			defer func() {
+				if recover() != nil { // nolint: a malformed entry must not halt the chain
+					ready = true // drop it rather than retry it forever
+				}
+			}()
+			return apply(value)
+		}()
+		if ready {
+			keys = append(keys, append([]byte{}, key...))
+		}
+		return true
+	})
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// EndBlocker matures unbonding and commission-change queue entries, applies
+// them against bonds, recalculates voting power and returns the resulting
+// validator set diff against previous. currentTime gates entries that also
+// carry a CompletionTime: such an entry only leaves the queue once both its
+// height and its time have been reached.
+func EndBlocker(store QueueStore, bank BankKeeper, bonds DelegateeBonds, previous []*abci.Validator, currentHeight, currentTime uint64) (diff []*abci.Validator) {
+
+	popMatured(store, unbondQueuePrefix, currentHeight, func(value []byte) bool {
+		var elem QueueElemUnbond
+		wire.ReadBinaryBytes(value, &elem)
+		if elem.CompletionTime != 0 && currentTime < elem.CompletionTime {
+			return false
+		}
+		applyMaturedUnbond(bank, bonds, elem)
+		return true
+	})
+
+	popMatured(store, commQueuePrefix, currentHeight, func(value []byte) bool {
+		var elem QueueElemModComm
+		wire.ReadBinaryBytes(value, &elem)
+		if elem.CompletionTime != 0 && currentTime < elem.CompletionTime {
+			return false
+		}
+		applyMaturedCommChange(bonds, elem)
+		return true
+	})
+
+	matureRedelegations(store, currentHeight)
+
+	bonds.UpdateVotingPower()
+	return bonds.ValidatorsDiff(previous, maxVal)
+}
+
+// matureRedelegations drops every QueueElemRedelegate whose CompletionHeight
+// has been reached from the redelegation queue, lifting the restriction on
+// redelegating the destination bond tokens again. Unlike the unbond and
+// commission-change queues, redelegations are keyed per-delegator rather
+// than per-height, so the whole queue is scanned rather than range-popped.
+func matureRedelegations(store QueueStore, currentHeight uint64) {
+	queues, keys := loadRedelegationQueue(store)
+	for i, elems := range queues {
+		var pending []QueueElemRedelegate
+		for _, elem := range elems {
+			if currentHeight < elem.CompletionHeight {
+				pending = append(pending, elem)
+			}
+		}
+		if len(pending) == len(elems) {
+			continue // nothing matured for this delegator
+		}
+		queues[i] = pending
+	}
+	saveRedelegationQueue(store, keys, queues)
+}
+
+// applyMaturedUnbond pays out a matured unbonding entry, converting its
+// bond tokens to coins at the exchange rate captured at dequeue time (i.e.
+// now), not the rate at submission.
+func applyMaturedUnbond(bank BankKeeper, bonds DelegateeBonds, elem QueueElemUnbond) {
+	_, bond := bonds.Get(elem.Delegatee)
+	if bond == nil {
+		return // delegatee bond no longer exists; nothing to pay out from
+	}
+	payout := elem.BondTokens.Mul(bond.ExchangeRate)
+	bank.Transfer(bond.Account, elem.Account, payout)
+}
+
+// applyMaturedCommChange applies a matured commission-change entry to its
+// target DelegateeBond, clamping the requested CommChange to
+// MaxCommissionChangePerDay.
+func applyMaturedCommChange(bonds DelegateeBonds, elem QueueElemModComm) {
+	i, bond := bonds.Get(elem.Delegatee)
+	if bond == nil {
+		return
+	}
+	change := elem.CommChange
+	switch {
+	case change.GT(MaxCommissionChangePerDay):
+		change = MaxCommissionChangePerDay
+	case change.LT(Zero.Sub(MaxCommissionChangePerDay)):
+		change = Zero.Sub(MaxCommissionChangePerDay)
+	}
+	bond.Commission = bond.Commission.Add(change)
+	bonds[i] = *bond
+}
@@ -0,0 +1,97 @@
+package stake
+
+import (
+	crypto "github.com/tendermint/go-crypto"
+	wire "github.com/tendermint/go-wire"
+
+	"github.com/cosmos/cosmos-sdk"
+)
+
+const moduleName = "stake"
+
+// Name returns the name the stake module is registered under in the
+// multistore, used to prefix all of its keys.
+func Name() string {
+	return moduleName
+}
+
+// Candidate is the public, queryable view of a DelegateeBond.
+type Candidate = DelegateeBond
+
+var (
+	// CandidatesAddressesKey is the prefix for the set of candidate
+	// validator addresses, replacing the pubkey-keyed CandidatesPubKeysKey.
+	CandidatesAddressesKey = []byte("CandidatesAddresses")
+
+	// CandidatesPubKeysKey is the legacy, pubkey-keyed set of candidates.
+	// It is no longer written to after the address-based migration runs,
+	// but is read once by MigrateToAddressIndex to rebuild
+	// CandidatesAddressesKey from existing state on first boot.
+	CandidatesPubKeysKey = []byte("CandidatesPubKeys")
+
+	pubKeyIndexPrefix = []byte("CandidatePubKeyIndex/")
+)
+
+// GetCandidateKey returns the store key for a validator-candidate, keyed by
+// its address rather than its raw pubkey. The pubkey is only needed once,
+// at candidate-declaration time, to populate the secondary index returned
+// by GetPubKeyIndexKey.
+func GetCandidateKey(addr sdk.Actor) []byte {
+	return append([]byte("Candidate/"), addr.Address...)
+}
+
+// GetPubKeyIndexKey returns the store key of the secondary pubkey->address
+// index. It exists purely for bootstrapping: looking up the address a
+// pubkey registered with, before any bond/unbond/query call ever needs the
+// pubkey again.
+func GetPubKeyIndexKey(pk crypto.PubKey) []byte {
+	return append(pubKeyIndexPrefix, pk.Bytes()...)
+}
+
+// GetDelegatorBondKey returns the store key for a single delegator bond,
+// keyed by the delegator's and the validator's addresses.
+func GetDelegatorBondKey(delegator, delegatee sdk.Actor) []byte {
+	return append(GetDelegatorBondsKey(delegator), delegatee.Address...)
+}
+
+// GetDelegatorBondsKey returns the store key prefix under which all of a
+// delegator's bonds are stored.
+func GetDelegatorBondsKey(delegator sdk.Actor) []byte {
+	return append([]byte("DelegatorBonds/"), delegator.Address...)
+}
+
+// MigrationStore is the minimal persistent key-value interface
+// MigrateToAddressIndex needs: read a raw stored value, and write one back.
+type MigrationStore interface {
+	Get(key []byte) []byte
+	Set(key, value []byte)
+}
+
+// MigrateToAddressIndex rebuilds CandidatesAddressesKey and the pubkey->
+// address index from the legacy pubkey-keyed candidate set in store. It is
+// meant to be run once, on first boot after upgrading to address-based
+// candidate identification: it is a no-op if CandidatesAddressesKey is
+// already populated, or if there is no legacy CandidatesPubKeysKey to
+// migrate from.
+func MigrateToAddressIndex(store MigrationStore, addrOf func(crypto.PubKey) sdk.Actor) (addrs []sdk.Actor) {
+	if store.Get(CandidatesAddressesKey) != nil {
+		return nil // already migrated
+	}
+
+	bz := store.Get(CandidatesPubKeysKey)
+	if bz == nil {
+		return nil // nothing to migrate
+	}
+
+	var pks []crypto.PubKey
+	wire.ReadBinaryBytes(bz, &pks)
+
+	addrs = make([]sdk.Actor, 0, len(pks))
+	for _, pk := range pks {
+		addr := addrOf(pk)
+		addrs = append(addrs, addr)
+		store.Set(GetPubKeyIndexKey(pk), wire.BinaryBytes(addr))
+	}
+	store.Set(CandidatesAddressesKey, wire.BinaryBytes(addrs))
+	return addrs
+}
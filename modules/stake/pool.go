@@ -0,0 +1,152 @@
+package stake
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/modules/coin"
+)
+
+// FeePoolKey is the store key the global FeePool is persisted under.
+var FeePoolKey = []byte("FeePool")
+
+// FeePool is the single global pool of transaction fees collected by the
+// stake module. Fees accumulate here as blocks are processed and are paid
+// out lazily - on withdrawal - to validators and delegators, rather than
+// being pushed out to every bond on every block.
+//
+// Cumulative tracks, per denom, the running total of fees credited to the
+// pool per unit of voting power since genesis. An entity's withdrawable
+// share of a given denom is derived from Cumulative[denom] and the
+// entity's own FeeAdjustment[denom], so joining the pool late never
+// entitles an entity to fees collected before it had power.
+type FeePool struct {
+	Pool       coin.Coins         // fees collected and not yet withdrawn
+	Cumulative map[string]Decimal // cumulative fee-per-power credited since genesis, by denom
+}
+
+// AddFees credits newly collected transaction fees to the pool and, for
+// every denom present in fees, advances that denom's cumulative per-power
+// counter. totalPower is the total voting power of the validator set at
+// the time the fees were collected. When totalPower is zero the fees are
+// still added to Pool, but no cumulative counter advances.
+func (fp *FeePool) AddFees(fees coin.Coins, totalPower Decimal) {
+	fp.Pool = fp.Pool.Plus(fees)
+	if totalPower.Equal(Zero) {
+		return
+	}
+	if fp.Cumulative == nil {
+		fp.Cumulative = make(map[string]Decimal)
+	}
+	for _, c := range fees {
+		added := NewDecimal(c.Amount)
+		fp.Cumulative[c.Denom] = fp.Cumulative[c.Denom].Add(added.Quo(totalPower))
+	}
+}
+
+// sortedDenoms returns the keys of Cumulative in sorted order, so that
+// anything built by ranging over them in this order - coin.Coins in
+// particular - comes out deterministic across nodes rather than in Go's
+// randomized map-iteration order.
+func (fp FeePool) sortedDenoms() []string {
+	denoms := make([]string, 0, len(fp.Cumulative))
+	for denom := range fp.Cumulative {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+	return denoms
+}
+
+// withdrawable returns, for every denom the pool has ever collected, the
+// amount an entity holding the given power and per-denom adjustment is
+// currently entitled to withdraw. The result is sorted by denom, since it
+// ends up in coin.Coins arithmetic that must be deterministic.
+func (fp FeePool) withdrawable(power Decimal, adjustment map[string]Decimal) coin.Coins {
+	var out coin.Coins
+	for _, denom := range fp.sortedDenoms() {
+		share := fp.Cumulative[denom].Mul(power).Sub(adjustment[denom])
+		if !share.GT(Zero) {
+			continue
+		}
+		out = append(out, coin.Coin{Denom: denom, Amount: share.IntPart()})
+	}
+	return out
+}
+
+// settleAdjustment resets adjustment to newPower * Cumulative for every
+// denom the pool tracks, so the entity's next withdrawable() call starts
+// from zero again for fees already credited to it.
+func (fp FeePool) settleAdjustment(newPower Decimal) map[string]Decimal {
+	denoms := fp.sortedDenoms()
+	adjustment := make(map[string]Decimal, len(denoms))
+	for _, denom := range denoms {
+		adjustment[denom] = fp.Cumulative[denom].Mul(newPower)
+	}
+	return adjustment
+}
+
+// settleValidatorFees credits the validator's accrued share of the pool at
+// its old voting power to withdrawn, then resets the bond's adjustment
+// against newPower. This must be called immediately before VotingPower is
+// changed, so fees already earned aren't diluted or inflated by the
+// change.
+func (fp FeePool) settleValidatorFees(b *DelegateeBond, newPower Decimal) (withdrawn coin.Coins) {
+	withdrawn = fp.withdrawable(b.VotingPower, b.FeeAdjustment)
+	b.FeeAdjustment = fp.settleAdjustment(newPower)
+	return withdrawn
+}
+
+// settleDelegatorFees credits the delegator's accrued share of the pool at
+// its old bond tokens to withdrawn, then resets the bond's adjustment
+// against newBondTokens. This must be called immediately before
+// BondTokens is changed.
+func (fp FeePool) settleDelegatorFees(b *DelegatorBond, newBondTokens Decimal) (withdrawn coin.Coins) {
+	withdrawn = fp.withdrawable(b.BondTokens, b.FeeAdjustment)
+	b.FeeAdjustment = fp.settleAdjustment(newBondTokens)
+	return withdrawn
+}
+
+// WithdrawableFor returns the fees a delegator bond is currently entitled to
+// withdraw, without settling its adjustment. Used for read-only queries.
+func (fp FeePool) WithdrawableFor(b DelegatorBond) coin.Coins {
+	return fp.withdrawable(b.BondTokens, b.FeeAdjustment)
+}
+
+// WithdrawDelegatorFees returns the fees currently withdrawable by a
+// delegator bond and settles its adjustment so the same fees cannot be
+// withdrawn twice. The caller is responsible for crediting the returned
+// coins to the delegator's account.
+func (fp *FeePool) WithdrawDelegatorFees(b *DelegatorBond) (withdrawn coin.Coins) {
+	withdrawn = fp.settleDelegatorFees(b, b.BondTokens)
+	fp.Pool = fp.Pool.Minus(withdrawn)
+	return withdrawn
+}
+
+// WithdrawValidatorCommission returns the commission portion of a
+// validator's currently withdrawable fees, in every denom, and settles its
+// adjustment. The remainder (the non-commission share) stays in the pool
+// for the validator's delegators to withdraw individually.
+func (fp *FeePool) WithdrawValidatorCommission(b *DelegateeBond) (commission coin.Coins) {
+	total := fp.settleValidatorFees(b, b.VotingPower)
+	for _, c := range total {
+		amount := NewDecimal(c.Amount).Mul(b.Commission)
+		if amount.Equal(Zero) {
+			continue
+		}
+		commission = append(commission, coin.Coin{Denom: c.Denom, Amount: amount.IntPart()})
+	}
+	fp.Pool = fp.Pool.Minus(commission)
+	return commission
+}
+
+// EnsureFeesWithdrawn returns an error if a delegator bond still has fees
+// outstanding, in any denom, against the given pool. Bond, unbond and
+// edit-commission transactions must call this first, since they change
+// VotingPower or BondTokens and would otherwise silently forfeit or smear
+// the entity's already-accrued share.
+func EnsureFeesWithdrawn(fp FeePool, b DelegatorBond) error {
+	if len(fp.withdrawable(b.BondTokens, b.FeeAdjustment)) > 0 {
+		return fmt.Errorf("outstanding fees must be withdrawn before this transaction")
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package stake
+
+import (
+	"errors"
+	"strconv"
+
+	crypto "github.com/tendermint/go-crypto"
+	wire "github.com/tendermint/go-wire"
+
+	"github.com/cosmos/cosmos-sdk"
+)
+
+const redelegationsPrefix = "Redelegations/"
+
+var errNonPositiveAmount = errors.New("redelegation amount must be positive")
+
+// TxRedelegate moves a delegator's bond tokens from one delegatee to
+// another atomically, without a full unbond/rebond cycle. It still queues
+// through the same unbonding period as an unbond, via QueueElemRedelegate,
+// so slashing can still reach tokens in transit.
+type TxRedelegate struct {
+	SrcPubKey crypto.PubKey
+	DstPubKey crypto.PubKey
+	Amount    Decimal // bond tokens to move, denominated against the source's exchange rate
+}
+
+// NewTxRedelegate wraps a TxRedelegate as an sdk.Tx.
+func NewTxRedelegate(src, dst crypto.PubKey, amount Decimal) sdk.Tx {
+	return TxRedelegate{SrcPubKey: src, DstPubKey: dst, Amount: amount}.Wrap()
+}
+
+// Wrap - used to satisfy the sdk.TxInner interface
+func (tx TxRedelegate) Wrap() sdk.Tx {
+	return sdk.Tx{TxInner: tx}
+}
+
+// ValidateBasic - used to satisfy the sdk.TxInner interface
+func (tx TxRedelegate) ValidateBasic() error {
+	if tx.SrcPubKey.Empty() || tx.DstPubKey.Empty() {
+		return errCandidateEmpty
+	}
+	if !tx.Amount.GT(Zero) {
+		return errNonPositiveAmount
+	}
+	return nil
+}
+
+// NewRedelegateQueueElem builds the QueueElemRedelegate for a redelegation
+// of amount bond tokens from src to dst, submitted at currentHeight and
+// maturing at completionHeight. amount is translated through both
+// delegatees' exchange rates so BondTokensDst carries the equivalent value
+// at submission time, preserving the share-tracking invariant across the
+// move.
+func NewRedelegateQueueElem(src, dst DelegateeBond, amount Decimal, currentHeight, completionHeight uint64) QueueElemRedelegate {
+	coinsValue := amount.Mul(src.ExchangeRate)
+	dstTokens := coinsValue.Quo(dst.ExchangeRate)
+	return QueueElemRedelegate{
+		QueueElem:        QueueElem{Delegatee: src.Delegatee, HeightAtInit: currentHeight},
+		SrcDelegatee:     src.Delegatee,
+		DstDelegatee:     dst.Delegatee,
+		BondTokensSrc:    amount,
+		BondTokensDst:    dstTokens,
+		CompletionHeight: completionHeight,
+	}
+}
+
+// ParseDecimal parses a plain integer amount of bond tokens from the CLI
+// into a Decimal, for commands like redelegate that take a raw --amount
+// flag rather than a pre-parsed coin.Coins.
+func ParseDecimal(s string) (Decimal, error) {
+	amount, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return Zero, err
+	}
+	return NewDecimal(amount), nil
+}
+
+// GetRedelegationsKey returns the store key under which a delegator's
+// pending QueueElemRedelegate entries are stored as a single slice, so the
+// destination bond tokens can't be redelegated again until they mature.
+func GetRedelegationsKey(delegator sdk.Actor) []byte {
+	return append([]byte(redelegationsPrefix), delegator.Address...)
+}
+
+// loadRedelegationQueue reads every delegator's pending QueueElemRedelegate
+// slice out of store, alongside the key it was stored under, so entries can
+// be slashed or pruned at maturity and written back to the same slot.
+func loadRedelegationQueue(store QueueStore) (queues [][]QueueElemRedelegate, keys [][]byte) {
+	store.Iterate([]byte(redelegationsPrefix), prefixRangeEnd(redelegationsPrefix), func(key, value []byte) bool {
+		var elems []QueueElemRedelegate
+		wire.ReadBinaryBytes(value, &elems)
+		queues = append(queues, elems)
+		keys = append(keys, append([]byte{}, key...))
+		return true
+	})
+	return queues, keys
+}
+
+// saveRedelegationQueue writes queues back to the keys they were loaded
+// from by loadRedelegationQueue, deleting any slot left empty.
+func saveRedelegationQueue(store QueueStore, keys [][]byte, queues [][]QueueElemRedelegate) {
+	for i, key := range keys {
+		if len(queues[i]) == 0 {
+			store.Delete(key)
+			continue
+		}
+		store.Set(key, wire.BinaryBytes(queues[i]))
+	}
+}
@@ -0,0 +1,283 @@
+package rest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	crypto "github.com/tendermint/go-crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/client/commands"
+	"github.com/cosmos/cosmos-sdk/client/commands/query"
+	"github.com/cosmos/cosmos-sdk/modules/coin"
+	"github.com/cosmos/cosmos-sdk/stack"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/gaia/modules/stake"
+)
+
+// RegisterRoutes wires the stake module's HTTP query and unsigned-tx
+// endpoints onto r, mirroring the stake CLI commands in
+// gaia/modules/stake/commands.
+func RegisterRoutes(r *mux.Router, cdc *wire.Codec) {
+	r.HandleFunc("/stake/candidates", queryCandidatesHandler(cdc)).Methods("GET")
+	r.HandleFunc("/stake/candidates/{pubkey}", queryCandidateHandler(cdc)).Methods("GET")
+	r.HandleFunc("/stake/delegators/{addr}/bonds", queryDelegatorCandidatesHandler(cdc)).Methods("GET")
+	r.HandleFunc("/stake/delegators/{addr}/bonds/{pubkey}", queryDelegatorBondHandler(cdc)).Methods("GET")
+
+	r.HandleFunc("/stake/delegate", buildDelegateTxHandler(cdc)).Methods("POST")
+	r.HandleFunc("/stake/unbond", buildUnbondTxHandler(cdc)).Methods("POST")
+	r.HandleFunc("/stake/redelegate", buildRedelegateTxHandler(cdc)).Methods("POST")
+}
+
+// queryOpts are the query-string options every read endpoint honors:
+// ?height= to pin a block, ?prove=true to verify the returned value against
+// its Merkle proof client-side before responding (mirroring --trust-node=false
+// on the CLI commands in gaia/modules/stake/commands). The proof itself is
+// not part of the response; a failed verification surfaces as a 500 instead.
+type queryOpts struct {
+	height uint64
+	prove  bool
+}
+
+func parseQueryOpts(r *http.Request) (queryOpts, error) {
+	opts := queryOpts{prove: r.URL.Query().Get("prove") == "true"}
+	if h := r.URL.Query().Get("height"); h != "" {
+		height, err := strconv.ParseUint(h, 10, 64)
+		if err != nil {
+			return opts, err
+		}
+		opts.height = height
+	}
+	return opts, nil
+}
+
+type queryResult struct {
+	Height uint64      `json:"height"`
+	Data   interface{} `json:"data"`
+}
+
+func writeQueryResult(w http.ResponseWriter, height uint64, data interface{}, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(queryResult{Height: height, Data: data})
+}
+
+func getParsed(key []byte, ptr interface{}, opts queryOpts) (uint64, error) {
+	return query.GetParsed(key, ptr, opts.height, opts.prove)
+}
+
+func queryCandidatesHandler(cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts, err := parseQueryOpts(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var addrs []sdk.Actor
+		key := stack.PrefixedKey(stake.Name(), stake.CandidatesAddressesKey)
+		height, err := getParsed(key, &addrs, opts)
+		writeQueryResult(w, height, addrs, err)
+	}
+}
+
+func queryCandidateHandler(cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts, err := parseQueryOpts(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		pk, err := decodePubKey(mux.Vars(r)["pubkey"])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		addr, err := addrFromPubKey(pk, opts)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		var candidate stake.Candidate
+		key := stack.PrefixedKey(stake.Name(), stake.GetCandidateKey(addr))
+		height, err := getParsed(key, &candidate, opts)
+		writeQueryResult(w, height, candidate, err)
+	}
+}
+
+func queryDelegatorCandidatesHandler(cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts, err := parseQueryOpts(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		delegator, err := parseDelegatorAddr(mux.Vars(r)["addr"])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var candidates []crypto.PubKey
+		key := stack.PrefixedKey(stake.Name(), stake.GetDelegatorBondsKey(delegator))
+		height, err := getParsed(key, &candidates, opts)
+		writeQueryResult(w, height, candidates, err)
+	}
+}
+
+func queryDelegatorBondHandler(cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts, err := parseQueryOpts(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		delegator, err := parseDelegatorAddr(mux.Vars(r)["addr"])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		pk, err := decodePubKey(mux.Vars(r)["pubkey"])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		addr, err := addrFromPubKey(pk, opts)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		var bond stake.DelegatorBond
+		key := stack.PrefixedKey(stake.Name(), stake.GetDelegatorBondKey(delegator, addr))
+		height, err := getParsed(key, &bond, opts)
+		writeQueryResult(w, height, bond, err)
+	}
+}
+
+func parseDelegatorAddr(s string) (sdk.Actor, error) {
+	addr, err := commands.ParseActor(s)
+	if err != nil {
+		return sdk.Actor{}, err
+	}
+	return coin.ChainAddr(addr), nil
+}
+
+// decodePubKey decodes a hex-encoded ed25519 pubkey from a URL path segment
+// or request body field.
+func decodePubKey(s string) (crypto.PubKey, error) {
+	var pk crypto.PubKeyEd25519
+	bz, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	copy(pk[:], bz)
+	return pk.Wrap(), nil
+}
+
+// addrFromPubKey resolves a candidate's address from the pubkey path
+// segment via the secondary pubkey->address index, mirroring the CLI's
+// --pubkey bootstrapping fallback in getValidatorAddr.
+func addrFromPubKey(pk crypto.PubKey, opts queryOpts) (sdk.Actor, error) {
+	var addr sdk.Actor
+	key := stack.PrefixedKey(stake.Name(), stake.GetPubKeyIndexKey(pk))
+	_, err := getParsed(key, &addr, opts)
+	return addr, err
+}
+
+// unsignedTxResponse wraps an unsigned sdk.Tx for client-side signing. No
+// keybase access happens on the server; the client is expected to sign and
+// broadcast this payload itself.
+type unsignedTxResponse struct {
+	Tx json.RawMessage `json:"tx"`
+}
+
+func writeUnsignedTx(cdc *wire.Codec, w http.ResponseWriter, tx sdk.Tx, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	bz, err := cdc.MarshalJSON(tx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(unsignedTxResponse{Tx: bz})
+}
+
+type delegateRequest struct {
+	PubKey string `json:"pubkey"`
+	Amount int64  `json:"amount"`
+}
+
+func buildDelegateTxHandler(cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req delegateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		pk, err := decodePubKey(req.PubKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		tx := stake.NewTxDelegate(pk, stake.NewDecimal(req.Amount))
+		writeUnsignedTx(cdc, w, tx, nil)
+	}
+}
+
+type unbondRequest struct {
+	PubKey string `json:"pubkey"`
+	Amount int64  `json:"amount"`
+}
+
+func buildUnbondTxHandler(cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req unbondRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		pk, err := decodePubKey(req.PubKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		tx := stake.NewTxUnbond(pk, stake.NewDecimal(req.Amount))
+		writeUnsignedTx(cdc, w, tx, nil)
+	}
+}
+
+type redelegateRequest struct {
+	SrcPubKey string `json:"src_pubkey"`
+	DstPubKey string `json:"dst_pubkey"`
+	Amount    int64  `json:"amount"`
+}
+
+func buildRedelegateTxHandler(cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req redelegateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		src, err := decodePubKey(req.SrcPubKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		dst, err := decodePubKey(req.DstPubKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		tx := stake.NewTxRedelegate(src, dst, stake.NewDecimal(req.Amount))
+		writeUnsignedTx(cdc, w, tx, nil)
+	}
+}
@@ -0,0 +1,117 @@
+package stake
+
+import (
+	"fmt"
+
+	crypto "github.com/tendermint/go-crypto"
+
+	"github.com/cosmos/cosmos-sdk"
+)
+
+// SlashParamsKey is the store key the global SlashParams are persisted under.
+var SlashParamsKey = []byte("SlashParams")
+
+// GetSigningInfoKey returns the store key for a validator-candidate's
+// SigningInfo, keyed by its registered pubkey.
+func GetSigningInfoKey(pk crypto.PubKey) []byte {
+	return append([]byte("SigningInfo/"), pk.Bytes()...)
+}
+
+// SlashResult communicates the outcome of a Slash call so the caller
+// (typically the BeginBlocker evidence handler) can emit it as an ABCI tag.
+type SlashResult struct {
+	Delegatee sdk.Actor
+	Fraction  Decimal
+	NewRate   Decimal
+}
+
+// Slash reduces a validator's ExchangeRate by fraction (floored at zero) in
+// response to evidence of misbehaviour, automatically diluting every
+// DelegatorBond against that delegatee without touching individual records.
+// Any QueueElemUnbond already in flight for the same delegatee that was
+// initiated at or after infractionHeight is slashed by the same fraction,
+// so stake can't dodge punishment by unbonding ahead of evidence inclusion.
+func (b DelegateeBonds) Slash(delegatee sdk.Actor, fraction Decimal, infractionHeight uint64, queue []QueueElemUnbond) (SlashResult, error) {
+
+	i, bond := b.Get(delegatee)
+	if bond == nil {
+		return SlashResult{}, fmt.Errorf("no delegatee bond found for %v", delegatee)
+	}
+
+	newRate := bond.ExchangeRate.Sub(bond.ExchangeRate.Mul(fraction))
+	if newRate.LT(Zero) {
+		newRate = Zero
+	}
+	bond.ExchangeRate = newRate
+	b[i] = *bond
+
+	for j, elem := range queue {
+		if elem.Delegatee.Equals(delegatee) && elem.HeightAtInit >= infractionHeight {
+			queue[j].BondTokens = elem.BondTokens.Sub(elem.BondTokens.Mul(fraction))
+		}
+	}
+
+	return SlashResult{Delegatee: delegatee, Fraction: fraction, NewRate: newRate}, nil
+}
+
+// SlashRedelegationQueue slashes the destination side of any pending
+// QueueElemRedelegate whose source is delegatee and whose submit height is
+// at or after infractionHeight, by the same fraction as Slash applies to
+// the source delegatee. This preserves the share-tracking invariant: tokens
+// in transit cannot be used to dodge a slash that would have applied had
+// they stayed put.
+func SlashRedelegationQueue(delegatee sdk.Actor, fraction Decimal, infractionHeight uint64, queue []QueueElemRedelegate) {
+	for j, elem := range queue {
+		if elem.SrcDelegatee.Equals(delegatee) && elem.HeightAtInit >= infractionHeight {
+			queue[j].BondTokensDst = elem.BondTokensDst.Sub(elem.BondTokensDst.Mul(fraction))
+		}
+	}
+}
+
+// Revoke jails a validator, excluding it from voting power without removing
+// its bond or delegations. Only a later Unrevoke clears the flag and lets
+// the validator return to the active set.
+func (b DelegateeBonds) Revoke(delegatee sdk.Actor) error {
+	i, bond := b.Get(delegatee)
+	if bond == nil {
+		return fmt.Errorf("no delegatee bond found for %v", delegatee)
+	}
+	bond.Jailed = true
+	b[i] = *bond
+	return nil
+}
+
+// Unrevoke lifts a prior Revoke, making the validator eligible for voting
+// power again on the next UpdateVotingPower call.
+func (b DelegateeBonds) Unrevoke(delegatee sdk.Actor) error {
+	i, bond := b.Get(delegatee)
+	if bond == nil {
+		return fmt.Errorf("no delegatee bond found for %v", delegatee)
+	}
+	bond.Jailed = false
+	b[i] = *bond
+	return nil
+}
+
+// SigningInfo tracks a validator's recent block-signing record so downtime
+// can be detected and penalized. MissedBitArray is a rolling bitmask over
+// the last SignedBlocksWindow blocks, indexed by IndexOffset modulo its
+// length; a set bit marks a block the validator did not sign.
+type SigningInfo struct {
+	StartHeight         uint64 // height at which BeginBlocker first recorded this validator's signing
+	IndexOffset         uint64 // offset into MissedBitArray of the next block to record
+	JailedUntil         uint64 // set to the slash height plus DowntimeJailDuration on a downtime slash; zero until then and never reset. Unrevoke does not check it
+	MissedBlocksCounter uint64 // running count of missed blocks within the current window
+	MissedBitArray      []bool // rolling record of missed (true) vs signed (false) blocks
+}
+
+// SlashParams are the governance-configurable parameters of the slashing
+// subsystem.
+type SlashParams struct {
+	MaxEvidenceAge          uint64  // maximum age, in blocks, for evidence to be valid
+	SignedBlocksWindow      uint64  // number of blocks to track for downtime detection
+	MinSignedPerWindow      Decimal // fraction of SignedBlocksWindow that must be signed to avoid a downtime slash
+	DowntimeJailDuration    uint64  // blocks added to the slash height to set SigningInfo.JailedUntil on a downtime slash
+	SlashFractionDoubleSign Decimal // fraction slashed for double-sign evidence
+	SlashFractionDowntime   Decimal // fraction slashed for downtime
+}
@@ -0,0 +1,59 @@
+package stake
+
+import (
+	"errors"
+
+	crypto "github.com/tendermint/go-crypto"
+
+	"github.com/cosmos/cosmos-sdk"
+)
+
+var errCandidateEmpty = errors.New("validator-candidate pubkey must be specified")
+
+// TxWithdrawFees withdraws a delegator's currently accrued share of the
+// global fee pool for the given validator-candidate.
+type TxWithdrawFees struct {
+	PubKey crypto.PubKey
+}
+
+// NewTxWithdrawFees wraps a TxWithdrawFees as an sdk.Tx.
+func NewTxWithdrawFees(pk crypto.PubKey) sdk.Tx {
+	return TxWithdrawFees{PubKey: pk}.Wrap()
+}
+
+// Wrap - used to satisfy the sdk.TxInner interface
+func (tx TxWithdrawFees) Wrap() sdk.Tx {
+	return sdk.Tx{TxInner: tx}
+}
+
+// ValidateBasic - used to satisfy the sdk.TxInner interface
+func (tx TxWithdrawFees) ValidateBasic() error {
+	if tx.PubKey.Empty() {
+		return errCandidateEmpty
+	}
+	return nil
+}
+
+// TxWithdrawCommission withdraws the commission fraction of a validator's
+// currently accrued share of the global fee pool.
+type TxWithdrawCommission struct {
+	PubKey crypto.PubKey
+}
+
+// NewTxWithdrawCommission wraps a TxWithdrawCommission as an sdk.Tx.
+func NewTxWithdrawCommission(pk crypto.PubKey) sdk.Tx {
+	return TxWithdrawCommission{PubKey: pk}.Wrap()
+}
+
+// Wrap - used to satisfy the sdk.TxInner interface
+func (tx TxWithdrawCommission) Wrap() sdk.Tx {
+	return sdk.Tx{TxInner: tx}
+}
+
+// ValidateBasic - used to satisfy the sdk.TxInner interface
+func (tx TxWithdrawCommission) ValidateBasic() error {
+	if tx.PubKey.Empty() {
+		return errCandidateEmpty
+	}
+	return nil
+}
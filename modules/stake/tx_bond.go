@@ -0,0 +1,67 @@
+package stake
+
+import (
+	crypto "github.com/tendermint/go-crypto"
+
+	"github.com/cosmos/cosmos-sdk"
+)
+
+// TxDelegate bonds coins to a validator-candidate, converting them to bond
+// tokens at the candidate's current ExchangeRate. It must not succeed while
+// the delegator has outstanding fees against any bond (see
+// EnsureFeesWithdrawn).
+type TxDelegate struct {
+	PubKey crypto.PubKey
+	Amount Decimal
+}
+
+// NewTxDelegate wraps a TxDelegate as an sdk.Tx.
+func NewTxDelegate(pk crypto.PubKey, amount Decimal) sdk.Tx {
+	return TxDelegate{PubKey: pk, Amount: amount}.Wrap()
+}
+
+// Wrap - used to satisfy the sdk.TxInner interface
+func (tx TxDelegate) Wrap() sdk.Tx {
+	return sdk.Tx{TxInner: tx}
+}
+
+// ValidateBasic - used to satisfy the sdk.TxInner interface
+func (tx TxDelegate) ValidateBasic() error {
+	if tx.PubKey.Empty() {
+		return errCandidateEmpty
+	}
+	if !tx.Amount.GT(Zero) {
+		return errNonPositiveAmount
+	}
+	return nil
+}
+
+// TxUnbond begins unbonding a delegator's bond tokens from a
+// validator-candidate, queuing the coins for release after the unbonding
+// period via QueueElemUnbond. It must not succeed while the delegator has
+// outstanding fees against the bond (see EnsureFeesWithdrawn).
+type TxUnbond struct {
+	PubKey crypto.PubKey
+	Amount Decimal
+}
+
+// NewTxUnbond wraps a TxUnbond as an sdk.Tx.
+func NewTxUnbond(pk crypto.PubKey, amount Decimal) sdk.Tx {
+	return TxUnbond{PubKey: pk, Amount: amount}.Wrap()
+}
+
+// Wrap - used to satisfy the sdk.TxInner interface
+func (tx TxUnbond) Wrap() sdk.Tx {
+	return sdk.Tx{TxInner: tx}
+}
+
+// ValidateBasic - used to satisfy the sdk.TxInner interface
+func (tx TxUnbond) ValidateBasic() error {
+	if tx.PubKey.Empty() {
+		return errCandidateEmpty
+	}
+	if !tx.Amount.GT(Zero) {
+		return errNonPositiveAmount
+	}
+	return nil
+}
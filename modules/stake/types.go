@@ -19,10 +19,12 @@ import (
 type DelegateeBond struct {
 	Delegatee       sdk.Actor
 	Commission      Decimal
-	ExchangeRate    Decimal   // Exchange rate for this validator's bond tokens (in millionths of coins)
-	TotalBondTokens Decimal   // Total number of bond tokens for the delegatee
-	Account         sdk.Actor // Account where the bonded coins are held. Controlled by the app
-	VotingPower     Decimal   // Last calculated voting power based on bond value
+	ExchangeRate    Decimal            // Exchange rate for this validator's bond tokens (in millionths of coins)
+	TotalBondTokens Decimal            // Total number of bond tokens for the delegatee
+	Account         sdk.Actor          // Account where the bonded coins are held. Controlled by the app
+	VotingPower     Decimal            // Last calculated voting power based on bond value
+	FeeAdjustment   map[string]Decimal // Adjustment factor against the fee pool's per-denom cumulative counters, set on every VotingPower change
+	Jailed          bool               // Whether the validator is currently jailed and excluded from the voting power calculation
 }
 
 // Validator - Get the validator from a bond value
@@ -72,22 +74,25 @@ func (b DelegateeBonds) UpdateVotingPower() (totalPower Decimal) {
 
 	// First update the voting power for all delegatees be sure to give no
 	// power to validators without the minimum atoms required to be a validator
-	for _, bv := range b {
-		vp := bv.TotalBondTokens.Mul(bv.ExchangeRate)
-		if vp.LT(minValBond) {
-			bv.VotingPower = Zero
-		} else {
-			bv.VotingPower = vp
+	for i := range b {
+		vp := b[i].TotalBondTokens.Mul(b[i].ExchangeRate)
+		switch {
+		case b[i].Jailed:
+			b[i].VotingPower = Zero // jailed validators never receive voting power
+		case vp.LT(minValBond):
+			b[i].VotingPower = Zero
+		default:
+			b[i].VotingPower = vp
 		}
 	}
 
 	// Now sort and truncate the power
 	b.Sort()
-	for i, bv := range b {
-		if i <= maxVal {
-			totalPower = totalPower.Add(bv.VotingPower)
+	for i := range b {
+		if i < maxVal {
+			totalPower = totalPower.Add(b[i].VotingPower)
 		} else {
-			bv.VotingPower = Zero
+			b[i].VotingPower = Zero
 		}
 	}
 	return
@@ -138,10 +143,12 @@ func getValidatorPower(set []*abci.Validator, pubKey []byte) uint64 {
 	return 0 // no power if not found
 }
 
-// Get - get a DelegateeBond for a specific validator from the DelegateeBonds
+// Get - get a DelegateeBond for a specific validator address from the
+// DelegateeBonds. Only the address is compared, not the full sdk.Actor
+// tuple, since a validator is now identified by address alone.
 func (b DelegateeBonds) Get(delegatee sdk.Actor) (int, *DelegateeBond) {
 	for i, bv := range b {
-		if bv.Delegatee.Equals(delegatee) {
+		if bytes.Equal(bv.Delegatee.Address, delegatee.Address) {
 			return i, &bv
 		}
 	}
@@ -165,19 +172,19 @@ func (b DelegateeBonds) Remove(i int) (DelegateeBonds, error) {
 // DelegatorBond represents some bond tokens held by an account.
 // It is owned by one delegator, and is associated with the voting power of one delegatee.
 type DelegatorBond struct {
-	Delegatee  sdk.Actor
-	BondTokens Decimal // amount of bond tokens
+	Delegatee     sdk.Actor
+	BondTokens    Decimal            // amount of bond tokens
+	FeeAdjustment map[string]Decimal // Adjustment factor against the fee pool's per-denom cumulative counters, set on every BondTokens change
 }
 
 // DelegatorBonds - all delegator bonds existing with multiple delegatees
 type DelegatorBonds []DelegatorBond
 
-// Get - get a DelegateeBond for a specific validator from the DelegateeBonds
+// Get - get a DelegatorBond for a specific validator address from the
+// DelegatorBonds. Only the address is compared, matching DelegateeBonds.Get.
 func (b DelegatorBonds) Get(delegatee sdk.Actor) (int, *DelegatorBond) {
 	for i, bv := range b {
-		if bytes.Equal(bv.Delegatee.Address, delegatee.Address) &&
-			bv.Delegatee.ChainID == delegatee.ChainID &&
-			bv.Delegatee.App == delegatee.App {
+		if bytes.Equal(bv.Delegatee.Address, delegatee.Address) {
 			return i, &bv
 		}
 	}
@@ -207,12 +214,30 @@ type QueueElem struct {
 // QueueElemUnbond - the unbonding queue element
 type QueueElemUnbond struct {
 	QueueElem
-	Account    sdk.Actor // account to pay out to
-	BondTokens Decimal   // amount of bond tokens which are unbonding
+	Account        sdk.Actor // account to pay out to
+	BondTokens     Decimal   // amount of bond tokens which are unbonding
+	CompletionTime uint64    // unix time at or after which this entry may mature, for chains that unbond by time rather than height
 }
 
 // QueueElemModComm - the commission queue element
 type QueueElemModComm struct {
 	QueueElem
-	CommChange Decimal // Proposed change in commission
+	CommChange     Decimal // Proposed change in commission
+	CompletionTime uint64  // unix time at or after which this entry may mature, for chains that unbond by time rather than height
+}
+
+// QueueElemRedelegate - the redelegation queue element. It moves bond
+// tokens from SrcDelegatee to DstDelegatee without a full unbond/rebond
+// cycle, while still holding the tokens to the same unbonding period for
+// slashing purposes: if SrcDelegatee is slashed before CompletionHeight,
+// BondTokensDst must be slashed by the same fraction (see
+// SlashRedelegationQueue, which BeginBlocker calls alongside
+// DelegateeBonds.Slash).
+type QueueElemRedelegate struct {
+	QueueElem
+	SrcDelegatee     sdk.Actor // delegatee the tokens are moving from; QueueElem.Delegatee holds the same value
+	DstDelegatee     sdk.Actor // delegatee the tokens are moving to
+	BondTokensSrc    Decimal   // bond tokens removed from the source, valued at the source's exchange rate at submit time
+	BondTokensDst    Decimal   // bond tokens credited to the destination, valued at the destination's exchange rate at submit time
+	CompletionHeight uint64    // height at which the destination bond tokens become transferable again
 }